@@ -516,6 +516,11 @@ var (
 		Value:    ethconfig.Defaults.RPCTxFeeCap,
 		Category: flags.APICategory,
 	}
+	ExchangeRPCFlag = &cli.StringFlag{
+		Name:     "rpc.exchange",
+		Usage:    "RPC endpoint used for on-chain exchange pool reads in newHeads subscriptions (default: " + filters.DefaultExchangeRPC + ")",
+		Category: flags.APICategory,
+	}
 	// Authenticated RPC HTTP settings
 	AuthListenFlag = &cli.StringFlag{
 		Name:     "authrpc.addr",
@@ -1513,6 +1518,12 @@ func setMiner(ctx *cli.Context, cfg *miner.Config) {
 	}
 }
 
+func setExchangeRPC(ctx *cli.Context, cfg *ethconfig.Config) {
+	if ctx.IsSet(ExchangeRPCFlag.Name) {
+		cfg.FilterExchangeRPC = ctx.String(ExchangeRPCFlag.Name)
+	}
+}
+
 func setRequiredBlocks(ctx *cli.Context, cfg *ethconfig.Config) {
 	requiredBlocks := ctx.String(EthRequiredBlocksFlag.Name)
 	if requiredBlocks == "" {
@@ -1718,6 +1729,7 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(RPCGlobalTxFeeCapFlag.Name) {
 		cfg.RPCTxFeeCap = ctx.Float64(RPCGlobalTxFeeCapFlag.Name)
 	}
+	setExchangeRPC(ctx, cfg)
 	if ctx.IsSet(NoDiscoverFlag.Name) {
 		cfg.EthDiscoveryURLs, cfg.SnapDiscoveryURLs = []string{}, []string{}
 	} else if ctx.IsSet(DNSDiscoveryFlag.Name) {
@@ -1887,7 +1899,14 @@ func RegisterGraphQLService(stack *node.Node, backend ethapi.Backend, filterSyst
 func RegisterFilterAPI(stack *node.Node, backend ethapi.Backend, ethcfg *ethconfig.Config) *filters.FilterSystem {
 	filterSystem := filters.NewFilterSystem(backend, filters.Config{
 		LogCacheSize: ethcfg.FilterLogCacheSize,
+		ExchangeRPC:  ethcfg.FilterExchangeRPC,
 	})
+	// The filters package already holds a client for the default endpoint.
+	if endpoint := filterSystem.ExchangeRPC(); endpoint != filters.DefaultExchangeRPC {
+		if err := filters.SetExchangeRPC(endpoint); err != nil {
+			Fatalf("Invalid exchange RPC endpoint %q: %v", endpoint, err)
+		}
+	}
 	stack.RegisterAPIs([]rpc.API{{
 		Namespace: "eth",
 		Service:   filters.NewFilterAPI(filterSystem, false),
@@ -18,8 +18,12 @@
 package utils
 
 import (
+	"flag"
 	"reflect"
 	"testing"
+
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/urfave/cli/v2"
 )
 
 func Test_SplitTagsFlag(t *testing.T) {
@@ -65,3 +69,33 @@ func Test_SplitTagsFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestSetExchangeRPC(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"unset", nil, ""},
+		{"set", []string{"--" + ExchangeRPCFlag.Name, "http://archive:8545"}, "http://archive:8545"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			set := flag.NewFlagSet("test", flag.ContinueOnError)
+			if err := ExchangeRPCFlag.Apply(set); err != nil {
+				t.Fatal(err)
+			}
+			if err := set.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			cfg := ethconfig.Defaults
+			setExchangeRPC(cli.NewContext(nil, set, nil), &cfg)
+			if cfg.FilterExchangeRPC != tt.want {
+				t.Errorf("FilterExchangeRPC = %q, want %q", cfg.FilterExchangeRPC, tt.want)
+			}
+		})
+	}
+}
@@ -128,6 +128,10 @@ type Config struct {
 	// This is the number of blocks for which logs will be cached in the filter system.
 	FilterLogCacheSize int
 
+	// RPC endpoint the filter system uses for on-chain exchange pool reads.
+	// Empty means filters.DefaultExchangeRPC.
+	FilterExchangeRPC string
+
 	// Mining options
 	Miner miner.Config
 
@@ -250,7 +250,7 @@ var allCurvePools []string
 var err error
 
 func init() {
-	fmt.Println("nickdebug NewHeads: init() called - 111pancake")
+	log.Debug("NewHeads: init() called")
 	numWorkers = runtime.NumCPU() - 1
 	if numWorkers < 1 {
 		numWorkers = 1 // Ensure at least one worker
@@ -288,9 +288,9 @@ func init() {
 	}
 	allCurvePools, err = GetAllPools_Curve()
 	if err != nil {
-		log.Error("nickdebug NewHeads: error getting allCurvePools: ", err)
+		log.Error("NewHeads: error getting allCurvePools", "err", err)
 	} else {
-		fmt.Println("nickdebug NewHeads: allCurvePools", allCurvePools)
+		log.Debug("NewHeads: loaded Curve pools", "count", len(allCurvePools))
 	}
 
 }
@@ -334,7 +334,7 @@ func curveWorker(id int, pools <-chan string, results chan<- PoolBalanceMetaData
 		balanceMetaData, err := GetBalanceMetaData_Curve(pool)
 		if err != nil {
 			// Handle error, perhaps log it
-			fmt.Printf("Worker %d: Error fetching balance metadata for Curve pool %s: %v\n", id, pool, err)
+			log.Error("curveWorker: error fetching balance metadata for Curve pool", "worker", id, "pool", pool, "err", err)
 		}
 
 		// Create PoolBalanceMetaData object
@@ -399,9 +399,9 @@ func logWorker(id int, logs <-chan *Log, results chan<- PoolBalanceMetaData, log
 		if err != nil {
 			switch e := err.(type) {
 			case WrongFactoryAddressError:
-				log.Info("NewHeads: pool has wrong factory address", "topicExchangeName", topicExchangeName, "address:", e.Address)
+				log.Info("NewHeads: pool has wrong factory address", "topicExchangeName", topicExchangeName, "address", e.Address)
 			default:
-				log.Error("NewHeads: error getting balanceMetaData: ", "topicExchangeName", topicExchangeName, "address:", address.Hex(), "error", err)
+				log.Error("NewHeads: error getting balanceMetaData", "topicExchangeName", topicExchangeName, "address", address.Hex(), "err", err)
 			}
 			balanceMetaData = interface{}(nil)
 		}
@@ -427,7 +427,7 @@ func oneInchWorker(id int, pools <-chan common.Address, results chan<- PoolBalan
 		// Example: Fetching pool data (placeholder logic)
 		balanceMetaData, err = GetBalanceMetaData_OneInchV2(poolAddress.Hex())
 		if err != nil {
-			log.Error("NewHeads: error getting balanceMetaData on OneinchV2: ", "address:", poolAddress.Hex(), "error", err)
+			log.Error("NewHeads: error getting balanceMetaData on OneinchV2", "address", poolAddress.Hex(), "err", err)
 		}
 
 		// Send the result back
@@ -482,7 +482,7 @@ func (api *FilterAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
 				// print the len of logs TODO nick remove this again
 				// log.Info("NewHeads: len(logs)", "count", len(logs))
 				if err != nil {
-					log.Error("NewHeads: error getting logs: ", err)
+					log.Error("NewHeads: error getting logs", "err", err)
 					continue
 				}
 
@@ -17,6 +17,10 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
+// DefaultExchangeRPC is the endpoint used for on-chain exchange reads unless
+// the filter system is configured otherwise.
+const DefaultExchangeRPC = "http://localhost:8545"
+
 var client *ethclient.Client
 var parsedABI_uniswapv2 abi.ABI
 var parsedABI_ERC20 abi.ABI
@@ -35,60 +39,63 @@ var blacklist_OneinchV2 map[string]bool
 var Balancerv2TestPoolIds []string
 var KnownEthereumAddresses []string
 var activeOneInchV2DecayPeriods_Lock sync.Mutex
+var tokenDecimalsCache map[common.Address]uint8
+var tokenDecimalsCache_Lock sync.Mutex
 
 func init() {
 	var err error
-	client, err = ethclient.Dial("http://localhost:8545")
+	client, err = ethclient.Dial(DefaultExchangeRPC)
 	if err != nil {
-		log.Error("Failed to connect to the Ethereum client: %v", err)
+		log.Error("Failed to connect to the Ethereum client", "err", err)
 	}
 
 	parsedABI_uniswapv2, err = abi.JSON(strings.NewReader(ABI_UniswapV2))
 	if err != nil {
-		log.Error("Failed to parse contract ABI: %v", err)
+		log.Error("Failed to parse contract ABI", "err", err)
 	}
 	parsedABI_uniswapv3_multicall, err = abi.JSON(strings.NewReader(ABI_UniswapV3_Multicall))
 	if err != nil {
-		log.Error("Failed to parse contract ABI: %v", err)
+		log.Error("Failed to parse contract ABI", "err", err)
 	}
 	parsedABI_uniswapv3_pool, err = abi.JSON(strings.NewReader(ABI_UniswapV3_Pool))
 	if err != nil {
-		log.Error("Failed to parse contract ABI: %v", err)
+		log.Error("Failed to parse contract ABI", "err", err)
 	}
 	parsedABI_pancakeswapv3_multicall, err = abi.JSON(strings.NewReader(ABI_PancakeSwapV3_Multicall))
 	if err != nil {
-		log.Error("Failed to parse contract ABI: %v", err)
+		log.Error("Failed to parse contract ABI", "err", err)
 	}
 	parsedABI_balancerv2_vault, err = abi.JSON(strings.NewReader(ABI_BalancerV2_Vault))
 	if err != nil {
-		log.Error("Failed to parse contract ABI: %v", err)
+		log.Error("Failed to parse contract ABI", "err", err)
 	}
 	parsedABI_balancerv2_pool, err = abi.JSON(strings.NewReader(ABI_BalancerV2_WeightedPool))
 	if err != nil {
-		log.Error("Failed to parse contract ABI: %v", err)
+		log.Error("Failed to parse contract ABI", "err", err)
 	}
 	parsedABI_OneInchV2_Mooniswap_Pool, err = abi.JSON(strings.NewReader(ABI_OneInchV2_Mooniswap_Pool))
 	if err != nil {
-		log.Error("Failed to parse contract ABI: %v", err)
+		log.Error("Failed to parse contract ABI", "err", err)
 	}
 	parsedABI_Curve_V2Pool, err = abi.JSON(strings.NewReader(ABI_Curve_V2Pool))
 	if err != nil {
-		log.Error("Failed to parse contract ABI: %v", err)
+		log.Error("Failed to parse contract ABI", "err", err)
 	}
 	parsedABI_Curve_V2Pool_2Tokens, err = abi.JSON(strings.NewReader(ABI_Curve_V2Pool_2Tokens))
 	if err != nil {
-		log.Error("Failed to parse contract ABI: %v", err)
+		log.Error("Failed to parse contract ABI", "err", err)
 	}
 	parsedABI_Curve_LPToken, err = abi.JSON(strings.NewReader(ABI_Curve_LPToken))
 	if err != nil {
-		log.Error("Failed to parse contract ABI: %v", err)
+		log.Error("Failed to parse contract ABI", "err", err)
 	}
 	parsedABI_ERC20, err = abi.JSON(strings.NewReader(ABI_ERC20))
 	if err != nil {
-		log.Error("Failed to parse contract ABI: %v", err)
+		log.Error("Failed to parse contract ABI", "err", err)
 	}
 
 	activeOneInchV2DecayPeriods = make(map[common.Address]OneInchV2DecayPeriod)
+	tokenDecimalsCache = make(map[common.Address]uint8)
 	// those pools are bugged, never have any TXs on it and cause div by zero errors which slows down the node and sometimes even crashes it
 	blacklistArray_OneinchV2 = []string{"0x22c6289db7e8eab6aa12c35a044410327c4d9f93", "0x642eef38c4a9bad89c264e8b567ab81b42373c09", "0xb30116c66483fd87de3cbe14e7e0a764b632e5ca", "0x3d1a7a585c5e6ce429e8bd74b2f44db3b643462d", "0x1a5dab604981cb24980b6b6646718f832ec7af3c", "0xf3cd77de1b99610441978bf542cae7d58673fb7d", "0xfde1ba8b3ef2d8a9d2e1665deb62a1a49fd4e4d1", "0xcad46eff448e22802359488b5873f5886242e438", "0xe64f91911883ea26c146fb094fab5dcec14a0925", "0xa7363cf21b379865eb04475e70901bc2fe0dbb5d", "0x5c2e1346c91313d55c6573276ba797e0d56f1b8e", "0x12e7d705025d5e5bd465d4153c1425cad5cefeb3", "0xd138b94dd76a63ed6683764760d030ee1f6017d7", "0x9cc0bf948954f90877624c09d2c5a28b0d40b2f7", "0x2c8b7fb5814c878805dc09544594977af1c0c3c2", "0x71c6e39a7945df102e9ef5d9f3534d3ca923d8f4", "0x339f882e761cb568127a674b61a4a835e2b97975", "0x713749988b4c6ed072fdc8c9063d0d10432f4743", "0xce7ae35e05ba226dcdd2ed23c974b7f90744c6d9", "0x0f0e6e11dccb4d08cd3e0e6501a9e5bd4fba94b8", "0xca6993d4a4e1ae19f98e64ee4069c43d759461ee", "0x611fcab149b34b857e8526cd1500c92f6ecae313", "0x5b445e5c775c93cddde2f307032dcb6efac6a594", "0x90f2fad22c3796ce5ac3a466e9a6c707bf72b512", "0xefa579e56923b876880c376280563b4d0232d2d1", "0x062d37257479435da4ba3c0a8c2e376da027e29b", "0x335565d37ac8554fe16dcb817514150d3059da1c", "0xb4cc12a091ff1778d19fd13461265dacb3f1599c", "0x45187b597f55a9bd9ef218bd833a4ac102d8ed34", "0x70c34a9b4d7a5c16f902a5274f9fb7ac18908daf", "0x98bdf79efc11a58bfe349ade4df8073cc452e283", "0x653a191f7b41319c4931f40c5b2e8b5c7bacc5b1", "0x4f339ff82ecf7efb987534df98602d0fafa4680f", "0xaf9f860bf2e67bd5227c04a8978d851740e94af3", "0x52959f4fd37c2e1f1ef45d48b9135fb523e0f831", "0xae1cddedd0320a6cadb1075d8094df2beb056bac", "0xc2946d6c8698cb0276b711757380d3ba43663576", "0x24df89e467b134a860c72f291297edd4e6f82c73", "0x084f67f06518572adf435d12abdfb455acaa90a1", "0x4020819c2c96962460fd1ce0c1eba8a52747d4a5", "0x6023cbb069ccbda746340e65e2b3094558e50d7d", "0xb2a26335f7215748f28001cf3c64dc5fadc9de43", "0x1a33e47f47318e5879998493e9de89966370fcb7", "0x18677c37f4142127598c872054e4dbb8cfbee202", "0x30e5d83276bc7ae54cb448df93e80212944d9ee9", "0x89bff5e0aec73979caac7ee2aa8c9a9773e3c9e0", "0x386b3ac9afab9e0f8a39d4cdd1585bcbda165f85", "0xfce443acd6aad3ea497dff392249bc75093d160f", "0x51a6315bf905b973409888dfd69a9958ae1982bf", "0x5267065e406cca5647551fd0239841bd280c1332", "0x94eb287e8b23908307b046dde327dc9feb8595fe", "0x57b7e58e427831a1a0fc0ceef11b21459c35ba3c", "0x304dceb0fabbafc08c02adcf492ddfc26a48f5ee", "0x2bc4b2bd0eab774a12e2a42df214cee1676bb6ff", "0xd688123dfffcb9c215def2ce6aa503f6e55717a7", "0xfebbe4cccbad7c6d6cff6272b1f3ad36bb1cc798", "0xa097d9d3a67b4b5cefe75fb97b6e817fb78b12d7", "0x9ac1359e4e70cfe77bb33fa659f809afefd7c64f", "0x23ecf94669570778afe5b14c8c320f285da42550", "0xe07c3809ac9fedebe249c8fdfc7615dc4c4cec60", "0x358a9447ee1d23b3995b70442d02dec221a7a7b9", "0x036d35985c250f394ae590807a27018aae225c2a"}
 	// Create a map for constant-time lookups
@@ -97,6 +104,20 @@ func init() {
 	KnownEthereumAddresses = []string{"0x0000000000000000000000000000000000000000", "0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"}
 }
 
+// SetExchangeRPC points the shared client used for all on-chain exchange reads
+// at the given endpoint. Dialing over http(s) is lazy, so only malformed URLs and
+// unsupported schemes are rejected; reachability is not checked. The client is
+// swapped without synchronization, so this must only be called during startup,
+// before any filter API is served.
+func SetExchangeRPC(endpoint string) error {
+	c, err := ethclient.Dial(endpoint)
+	if err != nil {
+		return err
+	}
+	client = c
+	return nil
+}
+
 type WrongFactoryAddressError struct {
 	Address string
 }
@@ -120,7 +141,7 @@ func AddPoolToActiveOneInchV2DecayPeriods(poolAddress common.Address, startBlock
 	callOpts := &bind.CallOpts{}
 	err := instance_OneInchV2_Mooniswap_Pool.Call(callOpts, &decayPeriodResponse, "decayPeriod")
 	if err != nil {
-		log.Info("Error fetching decayPeriod from contract:", "err", err)
+		log.Error("Failed to fetch OneInchV2 decayPeriod", "pool", poolAddress.Hex(), "err", err)
 		return // Consider handling the error appropriately
 	}
 	// log.Info("decayPeriodResponse", "decayPeriodResponse", decayPeriodResponse)
@@ -188,7 +209,7 @@ func GetBalanceMetaData_OneInchV2(poolAddress string) (MetaData_OneInchV2, error
 	callOpts := &bind.CallOpts{}
 	err := instance_OneInchV2_Mooniswap_Pool.Call(callOpts, &rawTokensResponse, "getTokens")
 	if err != nil {
-		log.Info("Error fetching tokens from contract:", "err", err)
+		log.Error("Failed to fetch OneInchV2 pool tokens", "pool", poolAddress, "err", err)
 		return metaData, err
 	}
 
@@ -196,7 +217,7 @@ func GetBalanceMetaData_OneInchV2(poolAddress string) (MetaData_OneInchV2, error
 	for _, rawTokenData := range rawTokensResponse {
 		addrSlice, ok := rawTokenData.([]common.Address)
 		if !ok {
-			log.Info("Error: Unexpected format for tokens in response")
+			log.Warn("Unexpected format for OneInchV2 pool tokens", "pool", poolAddress)
 			continue
 		}
 		tokens = append(tokens, addrSlice...)
@@ -206,7 +227,7 @@ func GetBalanceMetaData_OneInchV2(poolAddress string) (MetaData_OneInchV2, error
 	balancesForRemoval := make([]*big.Int, 0)
 	for i, token := range tokens {
 		if i > 1 {
-			log.Info("Warning: More than 2 tokens detected for pool: Skipping this pool due to faulty contract.", "poolAddress", poolAddress)
+			log.Warn("Skipping faulty OneInchV2 pool with more than 2 tokens", "pool", poolAddress)
 			return metaData, nil // Returning the current metaData without any further processing
 		}
 		// Get balance for addition
@@ -214,7 +235,7 @@ func GetBalanceMetaData_OneInchV2(poolAddress string) (MetaData_OneInchV2, error
 		err = instance_OneInchV2_Mooniswap_Pool.Call(callOpts, &balanceForAdditionResponse, "getBalanceForAddition", token)
 		if err != nil {
 			if isDivisionByZeroError(err) {
-				log.Info("Warning: Division by zero error detected for pool: Skipping this pool due to faulty contract.", "poolAddress", poolAddress)
+				log.Warn("Skipping faulty OneInchV2 pool, getBalanceForAddition divides by zero", "pool", poolAddress)
 				return metaData, nil // Returning the current metaData without any further processing
 			}
 			return metaData, err // For other errors
@@ -226,7 +247,7 @@ func GetBalanceMetaData_OneInchV2(poolAddress string) (MetaData_OneInchV2, error
 		err = instance_OneInchV2_Mooniswap_Pool.Call(callOpts, &balanceForRemovalResponse, "getBalanceForRemoval", token)
 		if err != nil {
 			if isDivisionByZeroError(err) {
-				log.Info("Warning: Division by zero error detected for pool:  Skipping this pool due to faulty contract.", "poolAddress", poolAddress)
+				log.Warn("Skipping faulty OneInchV2 pool, getBalanceForRemoval divides by zero", "pool", poolAddress)
 				return metaData, nil // Returning the current metaData without any further processing
 			}
 			return metaData, err // For other errors
@@ -336,7 +357,7 @@ func GetBalanceMetaData_BalancerV2(poolId common.Hash) (MetaData_BalancerV2, com
 	callOpts := &bind.CallOpts{}
 	err := instance_balancerv2_vault.Call(callOpts, &tokensAndBalances, "getPoolTokens", poolId)
 	if err != nil {
-		log.Info("GetBalanceMetaData_BalancerV2: Failed to retrieve value of variable:", "err", err)
+		log.Error("GetBalanceMetaData_BalancerV2: failed to retrieve pool tokens", "poolId", poolId, "err", err)
 		return metaData, poolAddress, err
 	}
 	addresses := tokensAndBalances[0].([]common.Address)
@@ -357,7 +378,7 @@ func GetBalanceMetaData_BalancerV2(poolId common.Hash) (MetaData_BalancerV2, com
 	var poolFee []interface{}
 	err = instance_balancerv2_weightedPool.Call(callOpts, &poolFee, "getSwapFeePercentage")
 	if err != nil {
-		log.Info("GetBalanceMetaData_BalancerV2: Failed to retrieve value of variable:", "err", err)
+		log.Error("GetBalanceMetaData_BalancerV2: failed to retrieve swap fee", "poolId", poolId, "err", err)
 		return metaData, poolAddress, err
 	}
 	fee_bigInt := poolFee[0].(*big.Int)
@@ -372,13 +393,13 @@ func GetBalanceMetaData_BalancerV2(poolId common.Hash) (MetaData_BalancerV2, com
 	err = instance_balancerv2_weightedPool.Call(callOpts, &poolScalingFactors, "getScalingFactors")
 	if err != nil {
 		if strings.Contains(err.Error(), "execution reverted") {
-			log.Info("The above execution reverted warning can be ignored. it is handled in the code and expected to happen.")
+			log.Debug("GetBalanceMetaData_BalancerV2: pool has no scaling factors", "poolId", poolId)
 			// The getScalingFactors function doesn't exist for this pool.
 			// Continue without logging an error.
 			metaData.ScalingFactors = nil // Explicitly set ScalingFactors to nil
 		} else {
 			// An unexpected error occurred.
-			log.Info("GetBalanceMetaData_BalancerV2: Failed to retrieve value of variable:", "err", err)
+			log.Error("GetBalanceMetaData_BalancerV2: failed to retrieve scaling factors", "poolId", poolId, "err", err)
 			return metaData, poolAddress, err
 		}
 	} else {
@@ -465,7 +486,7 @@ func GetBalanceMetaData_UniswapV3(poolAddress string) (ResponseStruct_UniswapV3M
 	getNAdjacentTickWordsInBothDirections := uint16(20)
 	err = instance_multicall.Call(callOpts, &response, "getExchangePriceInputData", poolAddressConverted, getNAdjacentTickWordsInBothDirections)
 	if err != nil {
-		log.Info("GetBalanceMetaData_UniswapV3: Failed to retrieve value of variable:", err)
+		log.Debug("GetBalanceMetaData_UniswapV3: failed to retrieve exchange price input data", "pool", poolAddress, "err", err)
 		return metaData, err
 	}
 
@@ -478,14 +499,14 @@ func GetBalanceMetaData_UniswapV3(poolAddress string) (ResponseStruct_UniswapV3M
 	var contractResponse ContractResponse
 	bytes, err := json.Marshal(response[0])
 	if err != nil {
-		log.Info("GetBalanceMetaData_UniswapV3: failed to marshal response[0]:", err)
+		log.Debug("GetBalanceMetaData_UniswapV3: failed to marshal response[0]", "pool", poolAddress, "err", err)
 		return metaData, err
 	}
 
 	// Unmarshal the JSON bytes into a ContractResponse struct
 	err = json.Unmarshal(bytes, &contractResponse)
 	if err != nil {
-		log.Info("GetBalanceMetaData_UniswapV3: failed to unmarshal into ContractResponse:", err)
+		log.Debug("GetBalanceMetaData_UniswapV3: failed to unmarshal into ContractResponse", "pool", poolAddress, "err", err)
 		return metaData, err
 	}
 
@@ -853,7 +874,7 @@ func GetBalanceMetaData_UniswapV2(poolAddress string) ([]float64, error) {
 	callOpts := &bind.CallOpts{}
 	err := instance_uniswapV2.Call(callOpts, &reserves, "getReserves")
 	if err != nil {
-		fmt.Printf("Failed to retrieve value of variable: %v", err)
+		log.Debug("Failed to retrieve UniswapV2 reserves", "pool", poolAddress, "err", err)
 		return metaData, err
 	}
 
@@ -861,7 +882,7 @@ func GetBalanceMetaData_UniswapV2(poolAddress string) ([]float64, error) {
 	var token0Address []interface{}
 	err = instance_uniswapV2.Call(callOpts, &token0Address, "token0")
 	if err != nil {
-		fmt.Printf("Failed to retrieve value of variable: %v", err)
+		log.Debug("Failed to retrieve UniswapV2 token0", "pool", poolAddress, "err", err)
 		return metaData, err
 	}
 
@@ -869,49 +890,43 @@ func GetBalanceMetaData_UniswapV2(poolAddress string) ([]float64, error) {
 	var token1Address []interface{}
 	err = instance_uniswapV2.Call(callOpts, &token1Address, "token1")
 	if err != nil {
-		fmt.Printf("Failed to retrieve value of variable: %v", err)
+		log.Debug("Failed to retrieve UniswapV2 token1", "pool", poolAddress, "err", err)
 		return metaData, err
 	}
 
 	// get token0 decimals
-	var token0Decimals []interface{}
-	contractAddress = token0Address[0].(common.Address)
-	instance_token0 := bind.NewBoundContract(contractAddress, parsedABI_uniswapv2, client, client, client)
-	err = instance_token0.Call(callOpts, &token0Decimals, "decimals")
+	token0Decimals, err := GetTokenDecimals(token0Address[0].(common.Address))
 	if err != nil {
-		fmt.Printf("Failed to retrieve value of variable: %v", err)
+		log.Debug("Failed to retrieve token0 decimals", "pool", poolAddress, "err", err)
 		return metaData, err
 	}
 
 	// get token1 decimals
-	var token1Decimals []interface{}
-	contractAddress = token1Address[0].(common.Address)
-	instance_token1 := bind.NewBoundContract(contractAddress, parsedABI_uniswapv2, client, client, client)
-	err = instance_token1.Call(callOpts, &token1Decimals, "decimals")
+	token1Decimals, err := GetTokenDecimals(token1Address[0].(common.Address))
 	if err != nil {
-		fmt.Printf("Failed to retrieve value of variable: %v", err)
+		log.Debug("Failed to retrieve token1 decimals", "pool", poolAddress, "err", err)
 		return metaData, err
 	}
 
 	// convert reserves that are in wei units to ether units using the decimals
 	reserves0_bigInt, ok := reserves[0].(*big.Int)
 	if !ok {
-		fmt.Printf("Failed to assert type: %v", err)
-		return metaData, fmt.Errorf("failed to assert type: %v", err)
+		log.Debug("Failed to assert type of reserves0", "pool", poolAddress)
+		return metaData, fmt.Errorf("unexpected reserves[0] type %T", reserves[0])
 	}
-	token0Reserves, err := ConvertWeiUnitsToEtherUnits_UsingTokenAddress(reserves0_bigInt, token0Address[0].(common.Address).Hex())
+	token0Reserves, err := ConvertWeiUnitsToEtherUnits_UsingDecimals(reserves0_bigInt, int(token0Decimals))
 	if err != nil {
-		fmt.Printf("Failed to convert reserves0 to ether units: %v", err)
+		log.Debug("Failed to convert reserves0 to ether units", "pool", poolAddress, "err", err)
 		return metaData, err
 	}
 	reserves1_bigInt, ok := reserves[1].(*big.Int)
 	if !ok {
-		fmt.Printf("Failed to assert type: %v", err)
-		return metaData, fmt.Errorf("failed to assert type: %v", err)
+		log.Debug("Failed to assert type of reserves1", "pool", poolAddress)
+		return metaData, fmt.Errorf("unexpected reserves[1] type %T", reserves[1])
 	}
-	token1Reserves, err := ConvertWeiUnitsToEtherUnits_UsingTokenAddress(reserves1_bigInt, token1Address[0].(common.Address).Hex())
+	token1Reserves, err := ConvertWeiUnitsToEtherUnits_UsingDecimals(reserves1_bigInt, int(token1Decimals))
 	if err != nil {
-		fmt.Printf("Failed to convert reserves1 to ether units: %v", err)
+		log.Debug("Failed to convert reserves1 to ether units", "pool", poolAddress, "err", err)
 		return metaData, err
 	}
 
@@ -928,7 +943,7 @@ func ConvertWeiUnitsToEtherUnits_UsingTokenAddress(tokenAmount *big.Int, tokenAd
 	// Check if tokenAmount is nil, zero, or negative
 	if tokenAmount == nil || tokenAmount.Sign() <= 0 {
 		err := errors.New("tokenAmount is nil, zero, or negative")
-		fmt.Printf("Error: %v, tokenAmount: %v", err, tokenAmount)
+		log.Debug("Skipping token amount conversion", "tokenAmount", tokenAmount, "err", err)
 		return 0, err
 	}
 
@@ -945,30 +960,60 @@ func ConvertWeiUnitsToEtherUnits_UsingTokenAddress(tokenAmount *big.Int, tokenAd
 	// Check if tokenAddress is a valid Ethereum address
 	if !common.IsHexAddress(tokenAddress) {
 		err := fmt.Errorf("invalid token address: %s", tokenAddress)
-		fmt.Printf("Error: %v, tokenAddress: %s", err, tokenAddress)
+		log.Error("Invalid token address", "tokenAddress", tokenAddress)
 		return 0, err
 	}
-	var contractAddress common.Address = common.HexToAddress(tokenAddress)
-	instance_ERC20 := bind.NewBoundContract(contractAddress, parsedABI_ERC20, client, client, client)
 	// Get token decimals
-	var tokenDecimals []interface{}
-	callOpts := &bind.CallOpts{}
-	err := instance_ERC20.Call(callOpts, &tokenDecimals, "decimals")
+	tokenDecimals, err := GetTokenDecimals(common.HexToAddress(tokenAddress))
 	if err != nil {
-		fmt.Printf("Failed to retrieve token decimals: %v, tokenAddress: %s", err, tokenAddress)
+		log.Error("Failed to retrieve token decimals", "tokenAddress", tokenAddress, "err", err)
 		return 0, err // Return the error to the caller
 	}
-	if len(tokenDecimals) == 0 {
-		err := fmt.Errorf("tokenDecimals is empty")
-		fmt.Printf("Error: %v, tokenAddress: %s", err, tokenAddress)
-		return 0, err // Return an error indicating tokenDecimals is empty
-	}
 	// Convert tokenAmount that are in wei units to ether units using the decimals
-	tokenDecimals_float64 := float64(tokenDecimals[0].(uint8))
+	tokenDecimals_float64 := float64(tokenDecimals)
 	tokenAmount_float64 := new(big.Float).SetInt(tokenAmount)
 	tokenAmount_etherUnits, _ := new(big.Float).Quo(tokenAmount_float64, new(big.Float).Mul(big.NewFloat(math.Pow(10.0, tokenDecimals_float64)), big.NewFloat(1))).Float64()
 	return tokenAmount_etherUnits, nil // Return nil error on success
 }
+
+// GetTokenDecimals returns the decimals of an ERC20 token. Decimals never change for
+// a deployed token, so the result is cached after the first successful lookup.
+func GetTokenDecimals(tokenAddress common.Address) (uint8, error) {
+	tokenDecimalsCache_Lock.Lock()
+	decimals, ok := tokenDecimalsCache[tokenAddress]
+	tokenDecimalsCache_Lock.Unlock()
+	if ok {
+		return decimals, nil
+	}
+
+	instance_ERC20 := bind.NewBoundContract(tokenAddress, parsedABI_ERC20, client, client, client)
+	var tokenDecimals []interface{}
+	callOpts := &bind.CallOpts{}
+	err := instance_ERC20.Call(callOpts, &tokenDecimals, "decimals")
+	if err != nil {
+		return 0, err
+	}
+	if len(tokenDecimals) == 0 {
+		return 0, fmt.Errorf("tokenDecimals is empty")
+	}
+	decimals, ok = tokenDecimals[0].(uint8)
+	if !ok {
+		return 0, fmt.Errorf("unexpected type for token decimals: %T", tokenDecimals[0])
+	}
+
+	tokenDecimalsCache_Lock.Lock()
+	tokenDecimalsCache[tokenAddress] = decimals
+	tokenDecimalsCache_Lock.Unlock()
+	return decimals, nil
+}
+
+// ClearTokenMetadataCache drops all cached token decimals. Mostly useful for tests.
+func ClearTokenMetadataCache() {
+	tokenDecimalsCache_Lock.Lock()
+	tokenDecimalsCache = make(map[common.Address]uint8)
+	tokenDecimalsCache_Lock.Unlock()
+}
+
 // create a function that takes in tokemAmount as a bigInt and decimals as int and returns the balance in ether units
 func ConvertWeiUnitsToEtherUnits_UsingDecimals(tokenAmount *big.Int, decimals int) (float64, error) {
 	if tokenAmount == nil || tokenAmount.Sign() <= 0 {
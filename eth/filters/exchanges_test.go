@@ -0,0 +1,160 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package filters
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// newDecimalsServer starts a JSON-RPC endpoint answering every eth_call with the
+// given decimals, or with an error while fail is set. It counts eth_call requests.
+func newDecimalsServer(t *testing.T, decimals uint8, calls *atomic.Int32, fail *atomic.Bool) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		if req.Method == "eth_call" {
+			calls.Add(1)
+		}
+		if fail.Load() {
+			resp["error"] = map[string]interface{}{"code": -32000, "message": "execution reverted"}
+		} else {
+			resp["result"] = common.BytesToHash([]byte{decimals}).Hex()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	testClient, err := ethclient.Dial(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to dial test endpoint: %v", err)
+	}
+	old := client
+	client = testClient
+	t.Cleanup(func() {
+		client = old
+		srv.Close()
+	})
+}
+
+func TestTokenDecimalsCache(t *testing.T) {
+	ClearTokenMetadataCache()
+	defer ClearTokenMetadataCache()
+
+	var (
+		calls atomic.Int32
+		fail  atomic.Bool
+		token = common.HexToAddress("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+	)
+	newDecimalsServer(t, 6, &calls, &fail)
+
+	// A failed lookup must not be cached.
+	fail.Store(true)
+	if _, err := GetTokenDecimals(token); err == nil {
+		t.Fatal("expected error from failing endpoint")
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("wrong number of eth_call requests: have %d, want 1", calls.Load())
+	}
+
+	// The first successful lookup fills the cache, the second one is served from it.
+	fail.Store(false)
+	for i := 0; i < 2; i++ {
+		decimals, err := GetTokenDecimals(token)
+		if err != nil {
+			t.Fatalf("lookup %d: unexpected error: %v", i, err)
+		}
+		if decimals != 6 {
+			t.Fatalf("lookup %d: wrong decimals: have %d, want 6", i, decimals)
+		}
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("wrong number of eth_call requests: have %d, want 2", calls.Load())
+	}
+
+	// Conversions reuse the cached decimals as well.
+	amount, err := ConvertWeiUnitsToEtherUnits_UsingTokenAddress(big.NewInt(2500000), token.Hex())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if amount != 2.5 {
+		t.Fatalf("wrong amount: have %v, want 2.5", amount)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("conversion hit the endpoint: have %d eth_call requests, want 2", calls.Load())
+	}
+
+	// Clearing the cache forces a fresh lookup.
+	ClearTokenMetadataCache()
+	if _, err := GetTokenDecimals(token); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("wrong number of eth_call requests: have %d, want 3", calls.Load())
+	}
+}
+
+func TestExchangeRPCConfig(t *testing.T) {
+	if have := (Config{}).withDefaults().ExchangeRPC; have != DefaultExchangeRPC {
+		t.Fatalf("wrong default endpoint: have %q, want %q", have, DefaultExchangeRPC)
+	}
+	custom := "http://archive.example:8545"
+	if have := (Config{ExchangeRPC: custom}).withDefaults().ExchangeRPC; have != custom {
+		t.Fatalf("custom endpoint overwritten: have %q, want %q", have, custom)
+	}
+
+	// Building a filter system must not touch the shared client.
+	old := client
+	sys := NewFilterSystem(nil, Config{ExchangeRPC: custom})
+	if client != old {
+		t.Fatal("NewFilterSystem replaced the exchange client")
+	}
+	if have := sys.ExchangeRPC(); have != custom {
+		t.Fatalf("wrong endpoint on filter system: have %q, want %q", have, custom)
+	}
+}
+
+func TestSetExchangeRPC(t *testing.T) {
+	old := client
+	defer func() { client = old }()
+
+	if err := SetExchangeRPC("ftp://localhost:8545"); err == nil {
+		t.Fatal("expected error for unsupported scheme")
+	}
+	if client != old {
+		t.Fatal("failed dial replaced the exchange client")
+	}
+	if err := SetExchangeRPC("http://archive.example:8545"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client == old {
+		t.Fatal("exchange client was not replaced")
+	}
+}
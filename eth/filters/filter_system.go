@@ -43,6 +43,7 @@ import (
 type Config struct {
 	LogCacheSize int           // maximum number of cached blocks (default: 32)
 	Timeout      time.Duration // how long filters stay active (default: 5min)
+	ExchangeRPC  string        // endpoint for on-chain exchange reads (default: DefaultExchangeRPC)
 }
 
 func (cfg Config) withDefaults() Config {
@@ -52,6 +53,9 @@ func (cfg Config) withDefaults() Config {
 	if cfg.LogCacheSize == 0 {
 		cfg.LogCacheSize = 32
 	}
+	if cfg.ExchangeRPC == "" {
+		cfg.ExchangeRPC = DefaultExchangeRPC
+	}
 	return cfg
 }
 
@@ -93,6 +97,11 @@ func NewFilterSystem(backend Backend, config Config) *FilterSystem {
 	}
 }
 
+// ExchangeRPC returns the endpoint configured for on-chain exchange reads.
+func (sys *FilterSystem) ExchangeRPC() string {
+	return sys.cfg.ExchangeRPC
+}
+
 type logCacheElem struct {
 	logs []*types.Log
 	body atomic.Value